@@ -13,4 +13,15 @@ const (
 	defaultAddBatchSize = 5
 	defaultVectorDim    = 1024
 	defaultIdMaxLen     = 64
+	defaultPartition    = "default"
 )
+
+const (
+	defaultNList              = 1024
+	defaultHNSWM              = 16
+	defaultHNSWEfConstruction = 200
+)
+
+// defaultSparseDropRatio is the drop_ratio_build used for the SPARSE_INVERTED_INDEX built on
+// defaultFieldSparseVector when EmbeddingConfig.UseSparse is true.
+const defaultSparseDropRatio = 0.2
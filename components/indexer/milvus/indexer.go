@@ -2,6 +2,7 @@ package milvus
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components"
@@ -21,10 +22,127 @@ type IndexerConfig struct {
 	Collection string `json:"collection"`
 
 	EmbeddingConfig EmbeddingConfig `json:"embedding_config"`
+	SchemaConfig    SchemaConfig    `json:"schema_config"`
+	IndexConfig     IndexConfig     `json:"index_config"`
+
+	// Partition is written to when PartitionExtractor is nil or returns "". Defaults to
+	// defaultPartition ("default", auto-created on first write) when empty, so writes and
+	// retriever/milvus's matching default land in the same partition.
+	Partition string `json:"partition"`
+	// PartitionExtractor derives a per-document partition name, overriding Partition. Missing
+	// partitions are created automatically on first write.
+	PartitionExtractor func(doc *schema.Document) string `json:"-"`
+
+	// ConsistencyLevel is applied when the collection is auto-created. Defaults to Strong.
+	ConsistencyLevel ConsistencyLevel `json:"consistency_level"`
 
 	AddBatchSize int `json:"add_batch_size"`
 }
 
+// ConsistencyLevel selects the read/write consistency guarantee Milvus enforces for a
+// collection. See https://milvus.io/docs/consistency.md.
+type ConsistencyLevel string
+
+const (
+	ConsistencyLevelStrong     ConsistencyLevel = "Strong"
+	ConsistencyLevelSession    ConsistencyLevel = "Session"
+	ConsistencyLevelBounded    ConsistencyLevel = "Bounded"
+	ConsistencyLevelEventually ConsistencyLevel = "Eventually"
+)
+
+func (c ConsistencyLevel) milvusConsistencyLevel() entity.ConsistencyLevel {
+	switch c {
+	case ConsistencyLevelSession:
+		return entity.ClSession
+	case ConsistencyLevelBounded:
+		return entity.ClBounded
+	case ConsistencyLevelEventually:
+		return entity.ClEventually
+	default:
+		return entity.ClStrong
+	}
+}
+
+// IndexType selects the vector index Milvus builds for defaultFieldVector.
+type IndexType string
+
+const (
+	IndexTypeFlat    IndexType = "FLAT"
+	IndexTypeIvfFlat IndexType = "IVF_FLAT"
+	IndexTypeIvfSQ8  IndexType = "IVF_SQ8"
+	IndexTypeHNSW    IndexType = "HNSW"
+	IndexTypeDiskANN IndexType = "DISKANN"
+	IndexTypeScaNN   IndexType = "SCANN"
+)
+
+// MetricType selects the distance metric used to both build the index and score search results.
+type MetricType string
+
+const (
+	MetricTypeL2     MetricType = "L2"
+	MetricTypeIP     MetricType = "IP"
+	MetricTypeCosine MetricType = "COSINE"
+)
+
+// IndexConfig configures the vector index built for defaultFieldVector. The zero value builds a
+// FLAT index with the L2 metric.
+type IndexConfig struct {
+	IndexType  IndexType  `json:"index_type"`
+	MetricType MetricType `json:"metric_type"`
+
+	// NList is used by IVF_FLAT, IVF_SQ8 and SCANN. Defaults to defaultNList.
+	NList int `json:"nlist,omitempty"`
+	// M and EfConstruction are used by HNSW. Default to defaultHNSWM / defaultHNSWEfConstruction.
+	M              int `json:"m,omitempty"`
+	EfConstruction int `json:"ef_construction,omitempty"`
+}
+
+func (c *IndexConfig) milvusMetricType() entity.MetricType {
+	switch c.MetricType {
+	case MetricTypeIP:
+		return entity.IP
+	case MetricTypeCosine:
+		return entity.COSINE
+	default:
+		return entity.L2
+	}
+}
+
+func (c *IndexConfig) nlist() int {
+	if c.NList == 0 {
+		return defaultNList
+	}
+	return c.NList
+}
+
+func (c *IndexConfig) buildIndex() (entity.Index, error) {
+	metric := c.milvusMetricType()
+
+	switch c.IndexType {
+	case "", IndexTypeFlat:
+		return entity.NewIndexFlat(metric)
+	case IndexTypeIvfFlat:
+		return entity.NewIndexIvfFlat(metric, c.nlist())
+	case IndexTypeIvfSQ8:
+		return entity.NewIndexIvfSQ8(metric, c.nlist())
+	case IndexTypeHNSW:
+		m, efConstruction := c.M, c.EfConstruction
+		if m == 0 {
+			m = defaultHNSWM
+		}
+		if efConstruction == 0 {
+			efConstruction = defaultHNSWEfConstruction
+		}
+		return entity.NewIndexHNSW(metric, m, efConstruction)
+	case IndexTypeDiskANN:
+		return entity.NewIndexDISKANN(metric)
+	case IndexTypeScaNN:
+		return entity.NewIndexSCANN(metric, c.nlist(), false)
+	default:
+		return nil, fmt.Errorf("[IndexConfig] unsupported index type %q", c.IndexType)
+	}
+}
+
 type EmbeddingConfig struct {
 	UseBuiltin bool `json:"use_builtin"`
 	// ModelName 指定模型名称
@@ -42,6 +160,33 @@ type EmbeddingConfig struct {
 	Embedding embedding.Embedder
 }
 
+// SchemaConfig declares additional scalar fields beyond the default ID/content/vector columns,
+// so callers can store and later filter on their own metadata.
+type SchemaConfig struct {
+	// Fields are appended to the auto-created schema. DataType must be one of the scalar types
+	// Milvus supports (Int64, Float, Double, Bool, VarChar, JSON).
+	Fields []*entity.Field `json:"-"`
+
+	// MetadataExtractor derives the value of each Fields entry from a Document, keyed by field
+	// name. If nil, values are read directly from doc.MetaData using the field name as key.
+	MetadataExtractor func(doc *schema.Document) map[string]any `json:"-"`
+}
+
+func (c *SchemaConfig) extract(doc *schema.Document) map[string]any {
+	if c.MetadataExtractor != nil {
+		return c.MetadataExtractor(doc)
+	}
+
+	return doc.MetaData
+}
+
+// SparseEmbedder is an optional extension of embedding.Embedder that also produces sparse
+// vectors (e.g. BM25/SPLADE encoders). When EmbeddingConfig.UseSparse is true, the configured
+// Embedding is type-asserted against this interface to build the sparse_vector column.
+type SparseEmbedder interface {
+	EmbedSparse(ctx context.Context, texts []string) ([]map[uint32]float32, error)
+}
+
 type Indexer struct {
 	config *IndexerConfig
 	client client.Client
@@ -51,7 +196,10 @@ type Columns struct {
 	ID           *entity.ColumnVarChar
 	Content      *entity.ColumnVarChar
 	Vector       *entity.ColumnFloatVector
-	SparseVector *entity.ColumnFloatVector
+	SparseVector *entity.ColumnSparseFloatVector
+	// Metadata holds one entity.Column per SchemaConfig.Fields entry, built from
+	// SchemaConfig.MetadataExtractor's output, or from doc.MetaData when it is nil.
+	Metadata []entity.Column
 }
 
 func NewIndexer(ctx context.Context, config *IndexerConfig) (*Indexer, error) {
@@ -70,8 +218,11 @@ func NewIndexer(ctx context.Context, config *IndexerConfig) (*Indexer, error) {
 	if config.EmbeddingConfig.IdMaxLen == 0 {
 		config.EmbeddingConfig.IdMaxLen = defaultIdMaxLen
 	}
+	if config.Partition == "" {
+		config.Partition = defaultPartition
+	}
 
-	mc, err := client.NewClient(context.Background(), client.Config{
+	mc, err := client.NewClient(ctx, client.Config{
 		Address:  config.Address,
 		Username: config.Username,
 		Password: config.Password,
@@ -86,40 +237,76 @@ func NewIndexer(ctx context.Context, config *IndexerConfig) (*Indexer, error) {
 		return nil, err
 	}
 	if !has {
-		entitySchema := &entity.Schema{
-			CollectionName: config.Collection,
-			Description:    "this is the example collection for inser and search",
-			AutoID:         false,
-			Fields: []*entity.Field{
-				{
-					Name:       defaultFieldID,
-					DataType:   entity.FieldTypeVarChar,
-					PrimaryKey: true,
-					TypeParams: map[string]string{
-						entity.TypeParamMaxLength: strconv.Itoa(config.EmbeddingConfig.IdMaxLen),
-					},
+		fields := []*entity.Field{
+			{
+				Name:       defaultFieldID,
+				DataType:   entity.FieldTypeVarChar,
+				PrimaryKey: true,
+				TypeParams: map[string]string{
+					entity.TypeParamMaxLength: strconv.Itoa(config.EmbeddingConfig.IdMaxLen),
 				},
-				{
-					Name:     defaultFieldContent,
-					DataType: entity.FieldTypeVarChar,
-					TypeParams: map[string]string{
-						entity.TypeParamMaxLength: strconv.Itoa(65535),
-					},
+			},
+			{
+				Name:     defaultFieldContent,
+				DataType: entity.FieldTypeVarChar,
+				TypeParams: map[string]string{
+					entity.TypeParamMaxLength: strconv.Itoa(65535),
 				},
-				{
-					Name:     defaultFieldVector,
-					DataType: entity.FieldTypeFloatVector,
-					TypeParams: map[string]string{
-						entity.TypeParamDim: strconv.Itoa(config.EmbeddingConfig.VectorDim),
-					},
+			},
+			{
+				Name:     defaultFieldVector,
+				DataType: entity.FieldTypeFloatVector,
+				TypeParams: map[string]string{
+					entity.TypeParamDim: strconv.Itoa(config.EmbeddingConfig.VectorDim),
 				},
 			},
 		}
 
-		err = mc.CreateCollection(ctx, entitySchema, entity.DefaultShardNumber) // only 1 shard
+		if config.EmbeddingConfig.UseSparse {
+			fields = append(fields, &entity.Field{
+				Name:     defaultFieldSparseVector,
+				DataType: entity.FieldTypeSparseVector,
+			})
+		}
+
+		fields = append(fields, config.SchemaConfig.Fields...)
+
+		entitySchema := &entity.Schema{
+			CollectionName: config.Collection,
+			Description:    "this is the example collection for inser and search",
+			AutoID:         false,
+			Fields:         fields,
+		}
+
+		err = mc.CreateCollection(ctx, entitySchema, entity.DefaultShardNumber, // only 1 shard
+			client.WithConsistencyLevel(config.ConsistencyLevel.milvusConsistencyLevel()))
+		if err != nil {
+			return nil, err
+		}
+
+		idx, err := config.IndexConfig.buildIndex()
 		if err != nil {
 			return nil, err
 		}
+		if err = mc.CreateIndex(ctx, config.Collection, defaultFieldVector, idx, false); err != nil {
+			return nil, err
+		}
+
+		if config.EmbeddingConfig.UseSparse {
+			sparseIdx, err := entity.NewIndexSparseInverted(entity.IP, defaultSparseDropRatio)
+			if err != nil {
+				return nil, err
+			}
+			if err = mc.CreateIndex(ctx, config.Collection, defaultFieldSparseVector, sparseIdx, false); err != nil {
+				return nil, err
+			}
+		}
+
+		// Milvus refuses to load a collection with any unindexed vector field, so the sparse
+		// index above must be created before this call.
+		if err = mc.LoadCollection(ctx, config.Collection, false); err != nil {
+			return nil, err
+		}
 	} else {
 		//err = mc.DropCollection(ctx, config.Collection)
 		//if err != nil {
@@ -132,10 +319,6 @@ func NewIndexer(ctx context.Context, config *IndexerConfig) (*Indexer, error) {
 		client: mc,
 	}
 
-	if config.EmbeddingConfig.UseBuiltin {
-		i.embModel = &models.NewTextEmbeddingFunction
-	}
-
 	return i, nil
 }
 
@@ -154,21 +337,87 @@ func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...in
 
 	ids = make([]string, 0, len(docs))
 	for _, sub := range chunk(docs, i.config.AddBatchSize) {
-		columns, err := i.convertDocuments(ctx, sub, options)
-		if err != nil {
-			return nil, fmt.Errorf("convertDocuments failed: %w", err)
+		for _, group := range i.groupByPartition(sub) {
+			if err = i.ensurePartition(ctx, group.partition); err != nil {
+				return nil, fmt.Errorf("ensurePartition failed: %w", err)
+			}
+
+			columns, err := i.convertDocuments(ctx, group.docs, options)
+			if err != nil {
+				return nil, fmt.Errorf("convertDocuments failed: %w", err)
+			}
+
+			upsertColumns := []entity.Column{columns.ID, columns.Content, columns.Vector}
+			if columns.SparseVector != nil {
+				upsertColumns = append(upsertColumns, columns.SparseVector)
+			}
+			upsertColumns = append(upsertColumns, columns.Metadata...)
+
+			if _, err = i.client.Upsert(ctx, i.config.Collection, group.partition, upsertColumns...); err != nil {
+				return nil, fmt.Errorf("Upsert failed: %v", err)
+			}
+
+			ids = append(ids, iter(group.docs, func(t *schema.Document) string { return t.ID })...)
 		}
+	}
+
+	ctx = callbacks.OnEnd(ctx, &indexer.CallbackOutput{IDs: ids})
+
+	return ids, nil
+}
+
+type partitionGroup struct {
+	partition string
+	docs      []*schema.Document
+}
 
-		if _, err = i.client.Upsert(ctx, i.config.Collection, "", columns.ID, columns.Content, columns.Vector); err != nil {
-			return nil, fmt.Errorf("Upsert failed: %v", err)
+// groupByPartition splits docs into partitionGroups, preserving first-seen partition order, so
+// Store can batch one Upsert per partition.
+func (i *Indexer) groupByPartition(docs []*schema.Document) []partitionGroup {
+	order := make([]string, 0)
+	byPartition := make(map[string][]*schema.Document)
+	for _, doc := range docs {
+		p := i.partitionOf(doc)
+		if _, ok := byPartition[p]; !ok {
+			order = append(order, p)
 		}
+		byPartition[p] = append(byPartition[p], doc)
+	}
 
-		ids = append(ids, iter(sub, func(t *schema.Document) string { return t.ID })...)
+	groups := make([]partitionGroup, len(order))
+	for idx, p := range order {
+		groups[idx] = partitionGroup{partition: p, docs: byPartition[p]}
 	}
 
-	ctx = callbacks.OnEnd(ctx, &indexer.CallbackOutput{IDs: ids})
+	return groups
+}
 
-	return ids, nil
+func (i *Indexer) partitionOf(doc *schema.Document) string {
+	if i.config.PartitionExtractor != nil {
+		if p := i.config.PartitionExtractor(doc); p != "" {
+			return p
+		}
+	}
+
+	return i.config.Partition
+}
+
+// ensurePartition creates partition if it doesn't already exist on the collection. The default
+// partition ("") always exists and is a no-op.
+func (i *Indexer) ensurePartition(ctx context.Context, partition string) error {
+	if partition == "" {
+		return nil
+	}
+
+	has, err := i.client.HasPartition(ctx, i.config.Collection, partition)
+	if err != nil {
+		return err
+	}
+	if has {
+		return nil
+	}
+
+	return i.client.CreatePartition(ctx, i.config.Collection, partition)
 }
 
 func (i *Indexer) convertDocuments(ctx context.Context, docs []*schema.Document, options *indexer.Options) (columns *Columns, err error) {
@@ -200,9 +449,120 @@ func (i *Indexer) convertDocuments(ctx context.Context, docs []*schema.Document,
 		Vector:  entity.NewColumnFloatVector(defaultFieldVector, i.getVectorDim(), vectors),
 	}
 
+	if i.config.EmbeddingConfig.UseSparse {
+		sparseEmb, ok := options.Embedding.(SparseEmbedder)
+		if !ok {
+			return nil, fmt.Errorf("[convertDocuments] UseSparse is true but Embedding does not implement SparseEmbedder")
+		}
+
+		sparseValues, err := sparseEmb.EmbedSparse(i.makeEmbeddingCtx(ctx, options.Embedding), queries)
+		if err != nil {
+			return nil, fmt.Errorf("EmbedSparse failed: %w", err)
+		}
+		if len(sparseValues) != size {
+			return nil, fmt.Errorf("[convertDocuments] invalid return length of sparse vector, got=%d, expected=%d", len(sparseValues), size)
+		}
+
+		sparseEmbeddings := make([]entity.SparseEmbedding, size)
+		for idx, values := range sparseValues {
+			sparseEmbeddings[idx], err = sparseEmbedding(values)
+			if err != nil {
+				return nil, fmt.Errorf("sparseEmbedding failed: %w", err)
+			}
+		}
+
+		columns.SparseVector = entity.NewColumnSparseVectors(defaultFieldSparseVector, sparseEmbeddings)
+	}
+
+	if len(i.config.SchemaConfig.Fields) > 0 {
+		columns.Metadata, err = i.buildMetadataColumns(docs)
+		if err != nil {
+			return nil, fmt.Errorf("buildMetadataColumns failed: %w", err)
+		}
+	}
+
 	return
 }
 
+// buildMetadataColumns builds one entity.Column per SchemaConfig.Fields entry, reading the
+// value for each document from SchemaConfig.extract keyed by field name.
+func (i *Indexer) buildMetadataColumns(docs []*schema.Document) ([]entity.Column, error) {
+	metadata := make([]map[string]any, len(docs))
+	for idx, doc := range docs {
+		metadata[idx] = i.config.SchemaConfig.extract(doc)
+	}
+
+	columns := make([]entity.Column, len(i.config.SchemaConfig.Fields))
+	for fi, field := range i.config.SchemaConfig.Fields {
+		switch field.DataType {
+		case entity.FieldTypeInt64:
+			values := make([]int64, len(docs))
+			for idx := range docs {
+				v, ok := metadata[idx][field.Name].(int64)
+				if !ok {
+					return nil, fmt.Errorf("[buildMetadataColumns] field %q: expected int64, got %T", field.Name, metadata[idx][field.Name])
+				}
+				values[idx] = v
+			}
+			columns[fi] = entity.NewColumnInt64(field.Name, values)
+		case entity.FieldTypeFloat:
+			values := make([]float32, len(docs))
+			for idx := range docs {
+				v, ok := metadata[idx][field.Name].(float32)
+				if !ok {
+					return nil, fmt.Errorf("[buildMetadataColumns] field %q: expected float32, got %T", field.Name, metadata[idx][field.Name])
+				}
+				values[idx] = v
+			}
+			columns[fi] = entity.NewColumnFloat(field.Name, values)
+		case entity.FieldTypeDouble:
+			values := make([]float64, len(docs))
+			for idx := range docs {
+				v, ok := metadata[idx][field.Name].(float64)
+				if !ok {
+					return nil, fmt.Errorf("[buildMetadataColumns] field %q: expected float64, got %T", field.Name, metadata[idx][field.Name])
+				}
+				values[idx] = v
+			}
+			columns[fi] = entity.NewColumnDouble(field.Name, values)
+		case entity.FieldTypeBool:
+			values := make([]bool, len(docs))
+			for idx := range docs {
+				v, ok := metadata[idx][field.Name].(bool)
+				if !ok {
+					return nil, fmt.Errorf("[buildMetadataColumns] field %q: expected bool, got %T", field.Name, metadata[idx][field.Name])
+				}
+				values[idx] = v
+			}
+			columns[fi] = entity.NewColumnBool(field.Name, values)
+		case entity.FieldTypeVarChar:
+			values := make([]string, len(docs))
+			for idx := range docs {
+				v, ok := metadata[idx][field.Name].(string)
+				if !ok {
+					return nil, fmt.Errorf("[buildMetadataColumns] field %q: expected string, got %T", field.Name, metadata[idx][field.Name])
+				}
+				values[idx] = v
+			}
+			columns[fi] = entity.NewColumnVarChar(field.Name, values)
+		case entity.FieldTypeJSON:
+			values := make([][]byte, len(docs))
+			for idx := range docs {
+				b, err := json.Marshal(metadata[idx][field.Name])
+				if err != nil {
+					return nil, fmt.Errorf("marshal metadata field %q failed: %w", field.Name, err)
+				}
+				values[idx] = b
+			}
+			columns[fi] = entity.NewColumnJSONBytes(field.Name, values)
+		default:
+			return nil, fmt.Errorf("[buildMetadataColumns] unsupported metadata field type %v for field %q", field.DataType, field.Name)
+		}
+	}
+
+	return columns, nil
+}
+
 func (i *Indexer) customEmbedding(ctx context.Context, queries []string, options *indexer.Options) (vectors [][]float32, err error) {
 	emb := options.Embedding
 	tempVectors, err := emb.EmbedStrings(i.makeEmbeddingCtx(ctx, emb), queries)
@@ -250,3 +610,8 @@ func (i *Indexer) IsCallbacksEnabled() bool {
 func (i *Indexer) getVectorDim() int {
 	return i.config.EmbeddingConfig.VectorDim
 }
+
+// Close releases the underlying Milvus client connection.
+func (i *Indexer) Close() error {
+	return i.client.Close()
+}
@@ -0,0 +1,113 @@
+package milvus
+
+import (
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// TestBuildMetadataColumnsTypeMismatch guards against silently writing the zero value when a
+// metadata field doesn't match its declared DataType, e.g. a doc.MetaData populated via
+// json.Unmarshal (numbers decode to float64, not int64).
+func TestBuildMetadataColumnsTypeMismatch(t *testing.T) {
+	i := &Indexer{
+		config: &IndexerConfig{
+			SchemaConfig: SchemaConfig{
+				Fields: []*entity.Field{{Name: "age", DataType: entity.FieldTypeInt64}},
+			},
+		},
+	}
+
+	docs := []*schema.Document{{MetaData: map[string]any{"age": float64(30)}}}
+
+	if _, err := i.buildMetadataColumns(docs); err == nil {
+		t.Fatal("buildMetadataColumns expected an error for a float64 value on an Int64 field, got nil")
+	}
+}
+
+func TestBuildMetadataColumnsHappyPath(t *testing.T) {
+	i := &Indexer{
+		config: &IndexerConfig{
+			SchemaConfig: SchemaConfig{
+				Fields: []*entity.Field{{Name: "category", DataType: entity.FieldTypeVarChar}},
+			},
+		},
+	}
+
+	docs := []*schema.Document{{MetaData: map[string]any{"category": "news"}}}
+
+	columns, err := i.buildMetadataColumns(docs)
+	if err != nil {
+		t.Fatalf("buildMetadataColumns returned error: %v", err)
+	}
+	if len(columns) != 1 {
+		t.Fatalf("buildMetadataColumns returned %d columns, want 1", len(columns))
+	}
+
+	col, ok := columns[0].(*entity.ColumnVarChar)
+	if !ok {
+		t.Fatalf("columns[0] is %T, want *entity.ColumnVarChar", columns[0])
+	}
+	value, err := col.ValueByIdx(0)
+	if err != nil {
+		t.Fatalf("ValueByIdx returned error: %v", err)
+	}
+	if value != "news" {
+		t.Errorf("columns[0][0] = %q, want %q", value, "news")
+	}
+}
+
+func TestPartitionOfPrefersExtractor(t *testing.T) {
+	i := &Indexer{
+		config: &IndexerConfig{
+			Partition: "default",
+			PartitionExtractor: func(doc *schema.Document) string {
+				return doc.MetaData["tenant"].(string)
+			},
+		},
+	}
+
+	doc := &schema.Document{MetaData: map[string]any{"tenant": "acme"}}
+	if got := i.partitionOf(doc); got != "acme" {
+		t.Errorf("partitionOf = %q, want %q", got, "acme")
+	}
+}
+
+func TestPartitionOfFallsBackWhenExtractorReturnsEmpty(t *testing.T) {
+	i := &Indexer{
+		config: &IndexerConfig{
+			Partition:          "default",
+			PartitionExtractor: func(doc *schema.Document) string { return "" },
+		},
+	}
+
+	if got := i.partitionOf(&schema.Document{}); got != "default" {
+		t.Errorf("partitionOf = %q, want %q", got, "default")
+	}
+}
+
+func TestGroupByPartitionPreservesFirstSeenOrder(t *testing.T) {
+	i := &Indexer{
+		config: &IndexerConfig{
+			PartitionExtractor: func(doc *schema.Document) string { return doc.MetaData["p"].(string) },
+		},
+	}
+
+	docs := []*schema.Document{
+		{ID: "1", MetaData: map[string]any{"p": "b"}},
+		{ID: "2", MetaData: map[string]any{"p": "a"}},
+		{ID: "3", MetaData: map[string]any{"p": "b"}},
+	}
+
+	groups := i.groupByPartition(docs)
+	if len(groups) != 2 {
+		t.Fatalf("groupByPartition returned %d groups, want 2", len(groups))
+	}
+	if groups[0].partition != "b" || groups[1].partition != "a" {
+		t.Errorf("groupByPartition order = [%q, %q], want [%q, %q]", groups[0].partition, groups[1].partition, "b", "a")
+	}
+	if len(groups[0].docs) != 2 || len(groups[1].docs) != 1 {
+		t.Errorf("groupByPartition sizes = [%d, %d], want [2, 1]", len(groups[0].docs), len(groups[1].docs))
+	}
+}
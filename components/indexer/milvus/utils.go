@@ -1,6 +1,11 @@
 package milvus
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
 
 func chunk[T any](slice []T, size int) [][]T {
 	if size <= 0 {
@@ -69,3 +74,20 @@ func interfaceToSparse(raw interface{}) (map[string]interface{}, error) {
 
 	return sparse, nil
 }
+
+// sparseEmbedding converts a {dimension: weight} sparse vector into the SDK's
+// entity.SparseEmbedding representation, ordering positions ascending as required by Milvus.
+func sparseEmbedding(values map[uint32]float32) (entity.SparseEmbedding, error) {
+	positions := make([]uint32, 0, len(values))
+	for pos := range values {
+		positions = append(positions, pos)
+	}
+	sort.Slice(positions, func(a, b int) bool { return positions[a] < positions[b] })
+
+	weights := make([]float32, len(positions))
+	for idx, pos := range positions {
+		weights[idx] = values[pos]
+	}
+
+	return entity.NewSliceSparseEmbedding(positions, weights)
+}
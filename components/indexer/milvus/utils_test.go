@@ -0,0 +1,58 @@
+package milvus
+
+import "testing"
+
+func TestSparseEmbeddingOrdersByPosition(t *testing.T) {
+	emb, err := sparseEmbedding(map[uint32]float32{3: 0.5, 1: 0.2, 2: 0.9})
+	if err != nil {
+		t.Fatalf("sparseEmbedding returned error: %v", err)
+	}
+
+	if emb.Len() != 3 {
+		t.Fatalf("emb.Len() = %d, want 3", emb.Len())
+	}
+
+	wantPositions := []uint32{1, 2, 3}
+	wantValues := []float32{0.2, 0.9, 0.5}
+	for idx := range wantPositions {
+		pos, value, ok := emb.Get(idx)
+		if !ok {
+			t.Fatalf("emb.Get(%d) returned ok=false", idx)
+		}
+		if pos != wantPositions[idx] || value != wantValues[idx] {
+			t.Errorf("emb.Get(%d) = (%d, %v), want (%d, %v)", idx, pos, value, wantPositions[idx], wantValues[idx])
+		}
+	}
+}
+
+func TestChunk(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+		in   []int
+		want [][]int
+	}{
+		{name: "even split", size: 2, in: []int{1, 2, 3, 4}, want: [][]int{{1, 2}, {3, 4}}},
+		{name: "remainder", size: 2, in: []int{1, 2, 3}, want: [][]int{{1, 2}, {3}}},
+		{name: "empty", size: 2, in: nil, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := chunk(tt.in, tt.size)
+			if len(got) != len(tt.want) {
+				t.Fatalf("chunk(%v, %d) = %v, want %v", tt.in, tt.size, got, tt.want)
+			}
+			for i := range got {
+				if len(got[i]) != len(tt.want[i]) {
+					t.Fatalf("chunk(%v, %d)[%d] = %v, want %v", tt.in, tt.size, i, got[i], tt.want[i])
+				}
+				for j := range got[i] {
+					if got[i][j] != tt.want[i][j] {
+						t.Errorf("chunk(%v, %d)[%d][%d] = %v, want %v", tt.in, tt.size, i, j, got[i][j], tt.want[i][j])
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package milvus
+
+const typ = "Milvus"
+
+const (
+	defaultFieldID           = "ID"
+	defaultFieldContent      = "content"
+	defaultFieldVector       = "vector"
+	defaultFieldSparseVector = "sparse_vector"
+
+	defaultReturnFieldID      = defaultFieldID
+	defaultReturnFieldContent = defaultFieldContent
+)
+
+const (
+	defaultNProbe     = 10
+	defaultEf         = 64
+	defaultSearchList = 100
+	defaultReorderK   = 100
+)
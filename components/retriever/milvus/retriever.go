@@ -3,6 +3,8 @@ package milvus
 import (
 	"context"
 	"fmt"
+	"time"
+
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components"
 	"github.com/cloudwego/eino/components/embedding"
@@ -29,6 +31,8 @@ type RetrieverConfig struct {
 	Index      string `json:"index"`
 
 	EmbeddingConfig EmbeddingConfig `json:"embedding_config"`
+	SchemaConfig    SchemaConfig    `json:"schema_config"`
+	SearchConfig    SearchConfig    `json:"search_config"`
 
 	// Partition 子索引划分字段, 索引中未配置时至空即可
 	Partition string `json:"partition"`
@@ -37,6 +41,62 @@ type RetrieverConfig struct {
 	ScoreThreshold *float64 `json:"score_threshold,omitempty"`
 	// FilterDSL 标量过滤 filter 表达式 https://www.volcengine.com/docs/84313/1254609
 	FilterDSL map[string]any `json:"filter_dsl,omitempty"`
+
+	// OutputFields lists the columns requested from Milvus, in addition to the always-included
+	// ID/content columns. Supports the Milvus wildcards "*" (all scalar fields) and "%" (all
+	// vector fields), which are expanded against the collection's schema. Overridable per call
+	// with WithOutputFields. Defaults to SchemaConfig.Fields when empty.
+	OutputFields []string `json:"output_fields,omitempty"`
+
+	// ConsistencyLevel is applied to every Search/HybridSearch call. Defaults to Strong.
+	ConsistencyLevel ConsistencyLevel `json:"consistency_level"`
+}
+
+// ConsistencyLevel mirrors indexer/milvus's ConsistencyLevel — the consistency guarantee
+// requested for Search/HybridSearch calls against the collection.
+type ConsistencyLevel string
+
+const (
+	ConsistencyLevelStrong     ConsistencyLevel = "Strong"
+	ConsistencyLevelSession    ConsistencyLevel = "Session"
+	ConsistencyLevelBounded    ConsistencyLevel = "Bounded"
+	ConsistencyLevelEventually ConsistencyLevel = "Eventually"
+)
+
+func (c ConsistencyLevel) milvusConsistencyLevel() entity.ConsistencyLevel {
+	switch c {
+	case ConsistencyLevelSession:
+		return entity.ClSession
+	case ConsistencyLevelBounded:
+		return entity.ClBounded
+	case ConsistencyLevelEventually:
+		return entity.ClEventually
+	default:
+		return entity.ClStrong
+	}
+}
+
+// ImplOptions holds Milvus-specific retriever.Option overrides.
+type ImplOptions struct {
+	OutputFields []string
+	// Partitions, when non-empty, searches this subset of partitions instead of the single
+	// partition configured via RetrieverConfig.Partition / retriever.WithSubIndex.
+	Partitions []string
+}
+
+// WithOutputFields overrides RetrieverConfig.OutputFields for a single Retrieve call.
+func WithOutputFields(fields []string) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.OutputFields = fields
+	})
+}
+
+// WithPartitions searches a caller-selected subset of partitions for a single Retrieve call,
+// overriding RetrieverConfig.Partition.
+func WithPartitions(partitions []string) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.Partitions = partitions
+	})
 }
 
 type EmbeddingConfig struct {
@@ -59,6 +119,113 @@ type EmbeddingConfig struct {
 	Embedding embedding.Embedder
 }
 
+// IndexType mirrors indexer/milvus's IndexType — the vector index built for the collection.
+type IndexType string
+
+const (
+	IndexTypeFlat    IndexType = "FLAT"
+	IndexTypeIvfFlat IndexType = "IVF_FLAT"
+	IndexTypeIvfSQ8  IndexType = "IVF_SQ8"
+	IndexTypeHNSW    IndexType = "HNSW"
+	IndexTypeDiskANN IndexType = "DISKANN"
+	IndexTypeScaNN   IndexType = "SCANN"
+)
+
+// MetricType mirrors indexer/milvus's MetricType — the metric the index was built with.
+type MetricType string
+
+const (
+	MetricTypeL2     MetricType = "L2"
+	MetricTypeIP     MetricType = "IP"
+	MetricTypeCosine MetricType = "COSINE"
+)
+
+// SearchConfig must match the IndexType/MetricType the collection's index was built with
+// (IndexerConfig.IndexConfig), so Retrieve builds a compatible entity.SearchParam and scores
+// results with the right metric.
+type SearchConfig struct {
+	IndexType  IndexType  `json:"index_type"`
+	MetricType MetricType `json:"metric_type"`
+
+	// NProbe is used when IndexType is IVF_FLAT, IVF_SQ8 or SCANN. Defaults to defaultNProbe.
+	NProbe int `json:"nprobe,omitempty"`
+	// Ef is used when IndexType is HNSW. Defaults to defaultEf.
+	Ef int `json:"ef,omitempty"`
+	// SearchList is used when IndexType is DISKANN. Defaults to defaultSearchList.
+	SearchList int `json:"search_list,omitempty"`
+	// ReorderK is used when IndexType is SCANN. Defaults to defaultReorderK.
+	ReorderK int `json:"reorder_k,omitempty"`
+}
+
+func (c *SearchConfig) milvusMetricType() entity.MetricType {
+	switch c.MetricType {
+	case MetricTypeIP:
+		return entity.IP
+	case MetricTypeCosine:
+		return entity.COSINE
+	default:
+		return entity.L2
+	}
+}
+
+func (c *SearchConfig) buildSearchParam() (entity.SearchParam, error) {
+	switch c.IndexType {
+	case "", IndexTypeFlat:
+		return entity.NewIndexFlatSearchParam()
+	case IndexTypeIvfFlat:
+		nprobe := c.NProbe
+		if nprobe == 0 {
+			nprobe = defaultNProbe
+		}
+		return entity.NewIndexIvfFlatSearchParam(nprobe)
+	case IndexTypeIvfSQ8:
+		nprobe := c.NProbe
+		if nprobe == 0 {
+			nprobe = defaultNProbe
+		}
+		return entity.NewIndexIvfSQ8SearchParam(nprobe)
+	case IndexTypeScaNN:
+		nprobe := c.NProbe
+		if nprobe == 0 {
+			nprobe = defaultNProbe
+		}
+		reorderK := c.ReorderK
+		if reorderK == 0 {
+			reorderK = defaultReorderK
+		}
+		return entity.NewIndexSCANNSearchParam(nprobe, reorderK)
+	case IndexTypeHNSW:
+		ef := c.Ef
+		if ef == 0 {
+			ef = defaultEf
+		}
+		return entity.NewIndexHNSWSearchParam(ef)
+	case IndexTypeDiskANN:
+		searchList := c.SearchList
+		if searchList == 0 {
+			searchList = defaultSearchList
+		}
+		return entity.NewIndexDISKANNSearchParam(searchList)
+	default:
+		return nil, fmt.Errorf("[SearchConfig] unsupported index type %q", c.IndexType)
+	}
+}
+
+// SchemaConfig declares the additional scalar fields an IndexerConfig.SchemaConfig stored
+// alongside each document, so the retriever knows which columns to request as output. FilterDSL
+// is translated without consulting these fields: any field name in it is passed through as is.
+type SchemaConfig struct {
+	// Fields mirrors indexer/milvus's SchemaConfig.Fields for the same collection.
+	Fields []*entity.Field `json:"-"`
+}
+
+// SparseEmbedder is an optional extension of embedding.Embedder that also produces sparse
+// vectors (e.g. BM25/SPLADE encoders). When EmbeddingConfig.UseSparse is true, the configured
+// Embedding is type-asserted against this interface to build the sparse leg of a hybrid search.
+type SparseEmbedder interface {
+	EmbedSparse(ctx context.Context, texts []string) ([]map[uint32]float32, error)
+}
+
 type Retriever struct {
 	config *RetrieverConfig
 	client client.Client
@@ -71,7 +238,14 @@ func NewRetriever(ctx context.Context, config *RetrieverConfig) (*Retriever, err
 		return nil, fmt.Errorf("[VikingDBRetriever] need provide Embedding when UseBuiltin embedding is false")
 	}
 
-	mc, err := client.NewClient(ctx, client.Config{
+	dialCtx := ctx
+	if config.ConnectionTimeout > 0 {
+		var cancel context.CancelFunc
+		dialCtx, cancel = context.WithTimeout(ctx, time.Duration(config.ConnectionTimeout)*time.Second)
+		defer cancel()
+	}
+
+	mc, err := client.NewClient(dialCtx, client.Config{
 		Address:  config.Address,
 		Username: config.Username,
 		Password: config.Password,
@@ -84,6 +258,12 @@ func NewRetriever(ctx context.Context, config *RetrieverConfig) (*Retriever, err
 	if len(config.Partition) == 0 {
 		config.Partition = defaultPartition
 	}
+	if config.TopK == nil {
+		config.TopK = ptrOf(defaultTopK)
+	}
+	if config.EmbeddingConfig.DenseWeight == 0 {
+		config.EmbeddingConfig.DenseWeight = defaultDenseWeight
+	}
 
 	r := &Retriever{
 		config: config,
@@ -108,10 +288,7 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		Embedding:      r.config.EmbeddingConfig.Embedding,
 		DSLInfo:        r.config.FilterDSL,
 	}, opts...)
-
-	var (
-		dense []float32
-	)
+	implOptions := retriever.GetImplSpecificOptions(&ImplOptions{OutputFields: r.config.OutputFields}, opts...)
 
 	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
 		Query:          query,
@@ -120,27 +297,49 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		ScoreThreshold: options.ScoreThreshold,
 	})
 
-	dense, err = r.customEmbedding(ctx, query, options)
-
+	dense, err := r.customEmbedding(ctx, query, options)
 	if err != nil {
 		return nil, err
 	}
-	vector := entity.FloatVector(dense)
-	sp, _ := entity.NewIndexFlatSearchParam()
-	result, err := r.client.Search(ctx, r.config.Collection, []string{}, "", []string{defaultReturnFieldID, defaultReturnFieldContent},
-		[]entity.Vector{vector}, defaultFieldVector, entity.L2, *r.config.TopK, sp)
+	denseVector := entity.FloatVector(dense)
+
+	expr, err := dslToExpr(options.DSLInfo)
+	if err != nil {
+		return nil, fmt.Errorf("dslToExpr failed: %w", err)
+	}
+
+	outputFields, err := r.resolveOutputFields(ctx, implOptions.OutputFields)
+	if err != nil {
+		return nil, fmt.Errorf("resolveOutputFields failed: %w", err)
+	}
+
+	partitions := implOptions.Partitions
+	if len(partitions) == 0 {
+		if p := dereferenceOrZero(options.SubIndex); p != "" {
+			partitions = []string{p}
+		}
+	}
+
+	var result []client.SearchResult
+	if sparseEmb, ok := options.Embedding.(SparseEmbedder); ok && r.config.EmbeddingConfig.UseSparse {
+		result, err = r.hybridSearch(ctx, query, denseVector, sparseEmb, expr, outputFields, partitions, options)
+	} else {
+		result, err = r.denseSearch(ctx, denseVector, expr, outputFields, partitions, options)
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	docs = make([]*schema.Document, 0, len(result))
 	for _, data := range result {
-		doc, err := r.data2Document(data)
+		hits, err := r.data2Documents(data)
 		if err != nil {
 			return nil, err
 		}
 
-		docs = append(docs, doc.WithDSLInfo(options.DSLInfo))
+		for _, doc := range hits {
+			docs = append(docs, doc.WithDSLInfo(options.DSLInfo))
+		}
 	}
 
 	ctx = callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
@@ -148,6 +347,109 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 	return docs, nil
 }
 
+// resolveOutputFields builds the final output-fields list for a Search/HybridSearch call: the
+// always-included ID/content columns, plus requested (falling back to SchemaConfig.Fields when
+// requested is empty), with the Milvus wildcards "*" (all scalar fields) and "%" (all vector
+// fields) expanded against the collection's live schema.
+func (r *Retriever) resolveOutputFields(ctx context.Context, requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		fields := []string{defaultReturnFieldID, defaultReturnFieldContent}
+		for _, field := range r.config.SchemaConfig.Fields {
+			fields = append(fields, field.Name)
+		}
+
+		return fields, nil
+	}
+
+	fields := dedupeStrings(append([]string{defaultReturnFieldID, defaultReturnFieldContent}, requested...))
+
+	hasWildcard := false
+	for _, f := range fields {
+		if f == "*" || f == "%" {
+			hasWildcard = true
+			break
+		}
+	}
+	if !hasWildcard {
+		return fields, nil
+	}
+
+	coll, err := r.client.DescribeCollection(ctx, r.config.Collection)
+	if err != nil {
+		return nil, fmt.Errorf("DescribeCollection failed: %w", err)
+	}
+
+	expanded := make([]string, 0, len(fields))
+	for _, f := range fields {
+		switch f {
+		case "*":
+			for _, field := range coll.Schema.Fields {
+				if !isVectorFieldType(field.DataType) {
+					expanded = append(expanded, field.Name)
+				}
+			}
+		case "%":
+			for _, field := range coll.Schema.Fields {
+				if isVectorFieldType(field.DataType) {
+					expanded = append(expanded, field.Name)
+				}
+			}
+		default:
+			expanded = append(expanded, f)
+		}
+	}
+
+	return dedupeStrings(expanded), nil
+}
+
+// denseSearch runs a plain vector search on the dense field. It is used whenever UseSparse is
+// false, or the configured Embedding does not implement SparseEmbedder.
+func (r *Retriever) denseSearch(ctx context.Context, vector entity.Vector, expr string, outputFields, partitions []string, options *retriever.Options) ([]client.SearchResult, error) {
+	sp, err := r.config.SearchConfig.buildSearchParam()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.client.Search(ctx, r.config.Collection, partitions, expr, outputFields,
+		[]entity.Vector{vector}, defaultFieldVector, r.config.SearchConfig.milvusMetricType(), dereferenceOrZero(options.TopK), sp,
+		client.WithSearchQueryConsistencyLevel(r.config.ConsistencyLevel.milvusConsistencyLevel()))
+}
+
+// hybridSearch fuses a dense search on defaultFieldVector with a sparse search on
+// defaultFieldSparseVector using a WeightedReranker, weighted by EmbeddingConfig.DenseWeight.
+func (r *Retriever) hybridSearch(ctx context.Context, query string, dense entity.Vector, sparseEmb SparseEmbedder, expr string, outputFields, partitions []string, options *retriever.Options) ([]client.SearchResult, error) {
+	sparseValues, err := sparseEmb.EmbedSparse(r.makeEmbeddingCtx(ctx, options.Embedding), []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("EmbedSparse failed: %w", err)
+	}
+	if len(sparseValues) != 1 {
+		return nil, fmt.Errorf("[hybridSearch] invalid return length of sparse vector, got=%d, expected=1", len(sparseValues))
+	}
+
+	sparse, err := sparseEmbedding(sparseValues[0])
+	if err != nil {
+		return nil, fmt.Errorf("sparseEmbedding failed: %w", err)
+	}
+
+	topK := dereferenceOrZero(options.TopK)
+
+	denseSP, err := r.config.SearchConfig.buildSearchParam()
+	if err != nil {
+		return nil, err
+	}
+	denseReq := client.NewANNSearchRequest(defaultFieldVector, r.config.SearchConfig.milvusMetricType(), expr, []entity.Vector{dense}, denseSP, topK)
+
+	sparseSP, _ := entity.NewIndexFlatSearchParam()
+	sparseReq := client.NewANNSearchRequest(defaultFieldSparseVector, entity.IP, expr, []entity.Vector{sparse}, sparseSP, topK)
+
+	denseWeight := r.config.EmbeddingConfig.DenseWeight
+	reranker := client.NewWeightedReranker([]float64{denseWeight, 1 - denseWeight})
+
+	return r.client.HybridSearch(ctx, r.config.Collection, partitions, topK,
+		outputFields, reranker, []*client.ANNSearchRequest{denseReq, sparseReq},
+		client.WithSearchQueryConsistencyLevel(r.config.ConsistencyLevel.milvusConsistencyLevel()))
+}
+
 func (r *Retriever) customEmbedding(ctx context.Context, query string, options *retriever.Options) (vector []float32, err error) {
 	emb := options.Embedding
 	tempVectors, err := emb.EmbedStrings(r.makeEmbeddingCtx(ctx, emb), []string{query})
@@ -182,43 +484,53 @@ func (r *Retriever) makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder
 	return callbacks.ReuseHandlers(ctx, runInfo)
 }
 
-func (r *Retriever) data2Document(data client.SearchResult) (*schema.Document, error) {
-	var idColumn *entity.ColumnVarChar
-	var contentColumn *entity.ColumnVarChar
-	for _, field := range data.Fields {
-		if field.Name() == defaultReturnFieldID {
-			c, ok := field.(*entity.ColumnVarChar)
-			if ok {
-				idColumn = c
+// data2Documents walks every hit in data (data.Fields/data.Scores are parallel columns, one entry
+// per hit up to data.ResultCount) and dispatches each column on its concrete type, rather than
+// only recognising the ID/content VarChar columns. ID and content are pulled out by name; anything
+// else (scalar metadata, the stored vector, the sparse vector, ...) lands in MetaData.
+func (r *Retriever) data2Documents(data client.SearchResult) ([]*schema.Document, error) {
+	if data.ResultCount == 0 {
+		return nil, fmt.Errorf("result field not math")
+	}
+
+	docs := make([]*schema.Document, data.ResultCount)
+	for idx := 0; idx < data.ResultCount; idx++ {
+		doc := &schema.Document{MetaData: map[string]any{}}
+
+		var hasID, hasContent bool
+		for _, field := range data.Fields {
+			value, err := columnValueByIdx(field, idx)
+			if err != nil {
+				return nil, fmt.Errorf("columnValueByIdx failed for field %q: %w", field.Name(), err)
 			}
-		} else if field.Name() == defaultReturnFieldContent {
-			c, ok := field.(*entity.ColumnVarChar)
-			if ok {
-				contentColumn = c
+
+			switch field.Name() {
+			case defaultReturnFieldID:
+				id, ok := value.(string)
+				if !ok {
+					return nil, fmt.Errorf("unexpected type for field %q: %T", field.Name(), value)
+				}
+				doc.ID, hasID = id, true
+			case defaultReturnFieldContent:
+				content, ok := value.(string)
+				if !ok {
+					return nil, fmt.Errorf("unexpected type for field %q: %T", field.Name(), value)
+				}
+				doc.Content, hasContent = content, true
+			default:
+				doc.MetaData[field.Name()] = value
 			}
 		}
-	}
-	if idColumn == nil || contentColumn == nil || data.ResultCount == 0 {
-		return nil, fmt.Errorf("result field not math")
-	}
+		if !hasID || !hasContent {
+			return nil, fmt.Errorf("result field not math")
+		}
 
-	id, err := idColumn.ValueByIdx(0)
-	if err != nil {
-		return nil, err
-	}
-	content, err := contentColumn.ValueByIdx(0)
-	if err != nil {
-		return nil, err
-	}
-	doc := &schema.Document{
-		ID:       id,
-		Content:  content,
-		MetaData: map[string]any{},
-	}
+		doc.WithScore(float64(data.Scores[idx]))
 
-	doc.WithScore(float64(data.Scores[0]))
+		docs[idx] = doc
+	}
 
-	return doc, nil
+	return docs, nil
 }
 
 func (r *Retriever) GetType() string {
@@ -228,3 +540,8 @@ func (r *Retriever) GetType() string {
 func (r *Retriever) IsCallbacksEnabled() bool {
 	return true
 }
+
+// Close releases the underlying Milvus client connection.
+func (r *Retriever) Close() error {
+	return r.client.Close()
+}
@@ -0,0 +1,60 @@
+package milvus
+
+import (
+	"testing"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// TestData2DocumentsReturnsOneDocPerHit guards against regressing to only ever returning the
+// first hit of a SearchResult: data.Fields/data.Scores are parallel columns holding every hit up
+// to data.ResultCount, not just one.
+func TestData2DocumentsReturnsOneDocPerHit(t *testing.T) {
+	r := &Retriever{}
+
+	result := client.SearchResult{
+		ResultCount: 2,
+		Fields: []entity.Column{
+			entity.NewColumnVarChar(defaultReturnFieldID, []string{"doc-1", "doc-2"}),
+			entity.NewColumnVarChar(defaultReturnFieldContent, []string{"hello", "world"}),
+		},
+		Scores: []float32{0.9, 0.5},
+	}
+
+	docs, err := r.data2Documents(result)
+	if err != nil {
+		t.Fatalf("data2Documents returned error: %v", err)
+	}
+	if len(docs) != 2 {
+		t.Fatalf("data2Documents returned %d docs, want 2", len(docs))
+	}
+
+	for idx, wantID := range []string{"doc-1", "doc-2"} {
+		if docs[idx].ID != wantID {
+			t.Errorf("docs[%d].ID = %q, want %q", idx, docs[idx].ID, wantID)
+		}
+	}
+	if docs[0].Content != "hello" || docs[1].Content != "world" {
+		t.Errorf("docs content = %q, %q, want %q, %q", docs[0].Content, docs[1].Content, "hello", "world")
+	}
+	if docs[0].Score() != float64(result.Scores[0]) || docs[1].Score() != float64(result.Scores[1]) {
+		t.Errorf("docs scores = %v, %v, want %v, %v", docs[0].Score(), docs[1].Score(), result.Scores[0], result.Scores[1])
+	}
+}
+
+func TestData2DocumentsMissingIDErrors(t *testing.T) {
+	r := &Retriever{}
+
+	result := client.SearchResult{
+		ResultCount: 1,
+		Fields: []entity.Column{
+			entity.NewColumnVarChar(defaultReturnFieldContent, []string{"hello"}),
+		},
+		Scores: []float32{0.9},
+	}
+
+	if _, err := r.data2Documents(result); err == nil {
+		t.Fatal("data2Documents expected an error when the ID column is missing, got nil")
+	}
+}
@@ -3,6 +3,11 @@ package milvus
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
 )
 
 func GetType() string {
@@ -48,3 +53,194 @@ func dereferenceOrZero[T any](v *T) T {
 func ptrOf[T any](v T) *T {
 	return &v
 }
+
+// sparseEmbedding converts a {dimension: weight} sparse vector into the SDK's
+// entity.SparseEmbedding representation, ordering positions ascending as required by Milvus.
+func sparseEmbedding(values map[uint32]float32) (entity.SparseEmbedding, error) {
+	positions := make([]uint32, 0, len(values))
+	for pos := range values {
+		positions = append(positions, pos)
+	}
+	sort.Slice(positions, func(a, b int) bool { return positions[a] < positions[b] })
+
+	weights := make([]float32, len(positions))
+	for idx, pos := range positions {
+		weights[idx] = values[pos]
+	}
+
+	return entity.NewSliceSparseEmbedding(positions, weights)
+}
+
+// dslToExpr translates a FilterDSL document into a Milvus boolean expression string suitable
+// for the Search/HybridSearch expr argument. Supported shapes:
+//
+//	{"field": value}                          -> field == value
+//	{"field": {"$in": [...]}}                 -> field in [...]
+//	{"field": {"$gte": v, "$lte": v}}         -> field >= v and field <= v (also $gt/$lt/$ne)
+//	{"$and": [dsl, dsl, ...]}                 -> (expr) and (expr)
+//	{"$or": [dsl, dsl, ...]}                  -> (expr) or (expr)
+func dslToExpr(dsl map[string]any) (string, error) {
+	if len(dsl) == 0 {
+		return "", nil
+	}
+
+	exprs := make([]string, 0, len(dsl))
+	for key, val := range dsl {
+		switch key {
+		case "$and":
+			expr, err := joinDSLList(val, " and ")
+			if err != nil {
+				return "", err
+			}
+			exprs = append(exprs, expr)
+		case "$or":
+			expr, err := joinDSLList(val, " or ")
+			if err != nil {
+				return "", err
+			}
+			exprs = append(exprs, expr)
+		default:
+			expr, err := fieldExpr(key, val)
+			if err != nil {
+				return "", err
+			}
+			exprs = append(exprs, expr)
+		}
+	}
+
+	return strings.Join(exprs, " and "), nil
+}
+
+func joinDSLList(val any, sep string) (string, error) {
+	list, ok := val.([]any)
+	if !ok {
+		return "", fmt.Errorf("dslToExpr: expected a list, got %v", val)
+	}
+
+	parts := make([]string, len(list))
+	for idx, item := range list {
+		node, ok := item.(map[string]any)
+		if !ok {
+			return "", fmt.Errorf("dslToExpr: expected a filter document, got %v", item)
+		}
+
+		expr, err := dslToExpr(node)
+		if err != nil {
+			return "", err
+		}
+		parts[idx] = "(" + expr + ")"
+	}
+
+	return strings.Join(parts, sep), nil
+}
+
+func fieldExpr(field string, val any) (string, error) {
+	ops, ok := val.(map[string]any)
+	if !ok {
+		return fmt.Sprintf("%s == %s", field, exprLiteral(val)), nil
+	}
+
+	conds := make([]string, 0, len(ops))
+	for op, opVal := range ops {
+		switch op {
+		case "$in":
+			list, ok := opVal.([]any)
+			if !ok {
+				return "", fmt.Errorf("dslToExpr: $in expects a list, got %v", opVal)
+			}
+			items := make([]string, len(list))
+			for idx, item := range list {
+				items[idx] = exprLiteral(item)
+			}
+			conds = append(conds, fmt.Sprintf("%s in [%s]", field, strings.Join(items, ", ")))
+		case "$gte":
+			conds = append(conds, fmt.Sprintf("%s >= %s", field, exprLiteral(opVal)))
+		case "$lte":
+			conds = append(conds, fmt.Sprintf("%s <= %s", field, exprLiteral(opVal)))
+		case "$gt":
+			conds = append(conds, fmt.Sprintf("%s > %s", field, exprLiteral(opVal)))
+		case "$lt":
+			conds = append(conds, fmt.Sprintf("%s < %s", field, exprLiteral(opVal)))
+		case "$ne":
+			conds = append(conds, fmt.Sprintf("%s != %s", field, exprLiteral(opVal)))
+		default:
+			return "", fmt.Errorf("dslToExpr: unsupported operator %q", op)
+		}
+	}
+
+	return strings.Join(conds, " and "), nil
+}
+
+func exprLiteral(v any) string {
+	switch t := v.(type) {
+	case string:
+		return strconv.Quote(t)
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(t)
+	default:
+		return fmt.Sprintf("%v", t)
+	}
+}
+
+// columnValueByIdx extracts the value at idx from col, dispatching on its concrete type.
+// Supported types cover everything a collection built by indexer/milvus can return: the scalar
+// field types, the stored dense vector, and the sparse vector.
+func columnValueByIdx(col entity.Column, idx int) (any, error) {
+	switch c := col.(type) {
+	case *entity.ColumnVarChar:
+		return c.ValueByIdx(idx)
+	case *entity.ColumnInt64:
+		return c.ValueByIdx(idx)
+	case *entity.ColumnFloat:
+		return c.ValueByIdx(idx)
+	case *entity.ColumnDouble:
+		return c.ValueByIdx(idx)
+	case *entity.ColumnBool:
+		return c.ValueByIdx(idx)
+	case *entity.ColumnJSONBytes:
+		raw, err := c.ValueByIdx(idx)
+		if err != nil {
+			return nil, err
+		}
+		var value any
+		if err = json.Unmarshal(raw, &value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	case *entity.ColumnFloatVector:
+		return c.Get(idx)
+	case *entity.ColumnSparseFloatVector:
+		return c.Get(idx)
+	default:
+		return nil, fmt.Errorf("columnValueByIdx: unsupported column type %T for field %q", col, col.Name())
+	}
+}
+
+// isVectorFieldType reports whether dt is one of Milvus's vector field types, used to expand
+// the "*" (scalar fields) and "%" (vector fields) output-field wildcards.
+func isVectorFieldType(dt entity.FieldType) bool {
+	switch dt {
+	case entity.FieldTypeFloatVector, entity.FieldTypeBinaryVector, entity.FieldTypeFloat16Vector,
+		entity.FieldTypeBFloat16Vector, entity.FieldTypeSparseVector:
+		return true
+	default:
+		return false
+	}
+}
+
+// dedupeStrings removes duplicate entries from vs, preserving first-seen order.
+func dedupeStrings(vs []string) []string {
+	seen := make(map[string]struct{}, len(vs))
+	out := make([]string, 0, len(vs))
+	for _, v := range vs {
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		out = append(out, v)
+	}
+
+	return out
+}
@@ -0,0 +1,148 @@
+package milvus
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+func TestDslToExprSimple(t *testing.T) {
+	tests := []struct {
+		name string
+		dsl  map[string]any
+		want string
+	}{
+		{
+			name: "empty",
+			dsl:  map[string]any{},
+			want: "",
+		},
+		{
+			name: "equality",
+			dsl:  map[string]any{"category": "news"},
+			want: `category == "news"`,
+		},
+		{
+			name: "in",
+			dsl:  map[string]any{"category": map[string]any{"$in": []any{"a", "b"}}},
+			want: `category in ["a", "b"]`,
+		},
+		{
+			name: "and",
+			dsl: map[string]any{"$and": []any{
+				map[string]any{"a": float64(1)},
+				map[string]any{"b": float64(2)},
+			}},
+			want: `(a == 1) and (b == 2)`,
+		},
+		{
+			name: "or",
+			dsl: map[string]any{"$or": []any{
+				map[string]any{"a": float64(1)},
+				map[string]any{"b": float64(2)},
+			}},
+			want: `(a == 1) or (b == 2)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := dslToExpr(tt.dsl)
+			if err != nil {
+				t.Fatalf("dslToExpr(%v) returned error: %v", tt.dsl, err)
+			}
+			if got != tt.want {
+				t.Errorf("dslToExpr(%v) = %q, want %q", tt.dsl, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestDslToExprRangeOperators covers a filter document with multiple operators on the same
+// field, whose iteration order isn't guaranteed, so it asserts on the joined conditions rather
+// than an exact string.
+func TestDslToExprRangeOperators(t *testing.T) {
+	dsl := map[string]any{
+		"score": map[string]any{"$gte": float64(1), "$lte": float64(10)},
+	}
+
+	got, err := dslToExpr(dsl)
+	if err != nil {
+		t.Fatalf("dslToExpr(%v) returned error: %v", dsl, err)
+	}
+
+	for _, want := range []string{"score >= 1", "score <= 10"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("dslToExpr(%v) = %q, want it to contain %q", dsl, got, want)
+		}
+	}
+	if !strings.Contains(got, " and ") {
+		t.Errorf("dslToExpr(%v) = %q, want conditions joined with \" and \"", dsl, got)
+	}
+}
+
+func TestDslToExprUnsupportedOperator(t *testing.T) {
+	dsl := map[string]any{"score": map[string]any{"$unknown": 1}}
+
+	if _, err := dslToExpr(dsl); err == nil {
+		t.Fatalf("dslToExpr(%v) expected an error for an unsupported operator, got nil", dsl)
+	}
+}
+
+func TestSparseEmbeddingOrdersByPosition(t *testing.T) {
+	emb, err := sparseEmbedding(map[uint32]float32{3: 0.5, 1: 0.2, 2: 0.9})
+	if err != nil {
+		t.Fatalf("sparseEmbedding returned error: %v", err)
+	}
+
+	if emb.Len() != 3 {
+		t.Fatalf("emb.Len() = %d, want 3", emb.Len())
+	}
+
+	wantPositions := []uint32{1, 2, 3}
+	wantValues := []float32{0.2, 0.9, 0.5}
+	for idx := range wantPositions {
+		pos, value, ok := emb.Get(idx)
+		if !ok {
+			t.Fatalf("emb.Get(%d) returned ok=false", idx)
+		}
+		if pos != wantPositions[idx] || value != wantValues[idx] {
+			t.Errorf("emb.Get(%d) = (%d, %v), want (%d, %v)", idx, pos, value, wantPositions[idx], wantValues[idx])
+		}
+	}
+}
+
+func TestColumnValueByIdxSparseVector(t *testing.T) {
+	emb, err := sparseEmbedding(map[uint32]float32{1: 0.2, 2: 0.9})
+	if err != nil {
+		t.Fatalf("sparseEmbedding returned error: %v", err)
+	}
+
+	col := entity.NewColumnSparseVectors(defaultFieldSparseVector, []entity.SparseEmbedding{emb})
+
+	value, err := columnValueByIdx(col, 0)
+	if err != nil {
+		t.Fatalf("columnValueByIdx returned error: %v", err)
+	}
+
+	got, ok := value.(entity.SparseEmbedding)
+	if !ok {
+		t.Fatalf("columnValueByIdx returned %T, want entity.SparseEmbedding", value)
+	}
+	if got.Len() != emb.Len() {
+		t.Errorf("columnValueByIdx: got.Len() = %d, want %d", got.Len(), emb.Len())
+	}
+}
+
+func TestColumnValueByIdxVarChar(t *testing.T) {
+	col := entity.NewColumnVarChar(defaultReturnFieldID, []string{"doc-1", "doc-2"})
+
+	value, err := columnValueByIdx(col, 1)
+	if err != nil {
+		t.Fatalf("columnValueByIdx returned error: %v", err)
+	}
+	if value != "doc-2" {
+		t.Errorf("columnValueByIdx(col, 1) = %v, want %q", value, "doc-2")
+	}
+}